@@ -0,0 +1,213 @@
+package lrucache
+
+import (
+	"fmt"
+	"sync"
+)
+
+// entry is a node of the intrusive doubly-linked list used by TypedCache[K, V].
+// Unlike payload/list.Element, key and value are stored inline so that
+// neither the list nor the map needs to box them in an interface{}.
+type entry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *entry[K, V]
+}
+
+//                              front                           back
+//                            +-------+       +-------+       +-------+
+//                            |       |------>|       |------>|       |
+// root <-> ... <-> root:     | entry |       | entry |       | entry |
+//                            |       |<------|       |<------|       |
+//                            +-------+       +-------+       +-------+
+//                                ^               ^               ^
+// items:                         |               |               |
+// map[K]*entry[K, V]             |               |               |
+//     +-----+---------------+    |               |               |
+//     | Key | *entry        +----+               |               |
+//     +-----+---------------+                    |               |
+//     | Key | *entry        +--------------------+               |
+//     +-----+---------------+                                    |
+//     | Key | *entry        +------------------------------------+
+//     +-----+---------------+
+//
+// root is a sentinel entry that is never stored in items; root.next is the
+// front (most recently used) and root.prev is the back (least recently used).
+
+// TypedCache is a thread-safe, fixed size, generic LRU cache, sitting
+// alongside the interface{}-based Cache. Keys and values are stored inline
+// in the map and the intrusive list, so there is no interface{} boxing, and
+// Add at capacity reuses the evicted node's storage instead of allocating a
+// new one.
+type TypedCache[K comparable, V any] struct {
+	mutex sync.Mutex
+	size  int
+	root  entry[K, V]
+	items map[K]*entry[K, V]
+}
+
+// NewTyped creates a generic LRU cache of the given size. if size<=0, will panic.
+func NewTyped[K comparable, V any](size int) *TypedCache[K, V] {
+	if size <= 0 {
+		panic(fmt.Sprintf("size must be > 0 and now == %d", size))
+	}
+	cache := &TypedCache[K, V]{
+		size:  size,
+		items: make(map[K]*entry[K, V], size),
+	}
+	cache.root.prev = &cache.root
+	cache.root.next = &cache.root
+	return cache
+}
+
+// Size returns the size of cache.
+func (cache *TypedCache[K, V]) Size() (size int) {
+	cache.mutex.Lock()
+	size = cache.size
+	cache.mutex.Unlock()
+	return
+}
+
+// SetSize sets a new size for the cache. if size <=0, we do nothing.
+func (cache *TypedCache[K, V]) SetSize(size int) {
+	if size <= 0 {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if n := len(cache.items) - size; n > 0 {
+		for i := 0; i < n; i++ {
+			cache.removeEntry(cache.root.prev)
+		}
+	}
+
+	cache.size = size
+	return
+}
+
+// Len returns the number of items in the cache.
+func (cache *TypedCache[K, V]) Len() (n int) {
+	cache.mutex.Lock()
+	n = len(cache.items)
+	cache.mutex.Unlock()
+	return
+}
+
+// Purge is used to completely clear the cache
+func (cache *TypedCache[K, V]) Purge() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.root.prev = &cache.root
+	cache.root.next = &cache.root
+	cache.items = make(map[K]*entry[K, V], cache.size)
+}
+
+// pushFront inserts e as the front (most recently used) entry.
+func (cache *TypedCache[K, V]) pushFront(e *entry[K, V]) {
+	e.prev = &cache.root
+	e.next = cache.root.next
+	e.prev.next = e
+	e.next.prev = e
+}
+
+// moveToFront moves e, which must already be in the list, to the front.
+func (cache *TypedCache[K, V]) moveToFront(e *entry[K, V]) {
+	if cache.root.next == e {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	cache.pushFront(e)
+}
+
+// add adds key-value to cache.
+// Please ensure that there is no item with the same key in cache
+func (cache *TypedCache[K, V]) add(key K, value V) {
+	if len(cache.items) >= cache.size {
+		e := cache.root.prev // e != &cache.root, for cache.size > 0
+
+		delete(cache.items, e.key)
+
+		e.key = key
+		e.value = value
+
+		cache.items[key] = e
+		cache.moveToFront(e)
+		return
+	}
+
+	e := &entry[K, V]{key: key, value: value}
+	cache.items[key] = e
+	cache.pushFront(e)
+}
+
+// removeEntry removes the entry e from the list.
+// Please ensure that e != &cache.root and e is an entry of the list.
+func (cache *TypedCache[K, V]) removeEntry(e *entry[K, V]) {
+	delete(cache.items, e.key)
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = nil
+	e.next = nil
+}
+
+// Add adds key-value to cache.
+// if there already exists a item with the same key, it returns ErrNotStored.
+func (cache *TypedCache[K, V]) Add(key K, value V) (err error) {
+	cache.mutex.Lock()
+	if _, hit := cache.items[key]; hit {
+		err = ErrNotStored
+		cache.mutex.Unlock() // Unlock
+		return
+	}
+	cache.add(key, value)
+	cache.mutex.Unlock() // Unlock
+	return
+}
+
+// Set sets key-value to cache, unconditional
+func (cache *TypedCache[K, V]) Set(key K, value V) (err error) {
+	cache.mutex.Lock()
+	if e, hit := cache.items[key]; hit {
+		e.value = value
+		cache.moveToFront(e)
+		cache.mutex.Unlock() // Unlock
+		return
+	}
+	cache.add(key, value)
+	cache.mutex.Unlock() // Unlock
+	return
+}
+
+// Get looks up a key's value from the cache.
+// if there is no such item with the key it returns ErrNotFound.
+func (cache *TypedCache[K, V]) Get(key K) (value V, err error) {
+	cache.mutex.Lock()
+	if e, hit := cache.items[key]; hit {
+		cache.moveToFront(e)
+		value = e.value
+		cache.mutex.Unlock() // Unlock
+		return
+	}
+	err = ErrNotFound
+	cache.mutex.Unlock() // Unlock
+	return
+}
+
+// Remove removes the provided key from the cache.
+// if there is no such item with the key it returns ErrNotFound,
+// normally you can ignore this error.
+func (cache *TypedCache[K, V]) Remove(key K) (err error) {
+	cache.mutex.Lock()
+	if e, hit := cache.items[key]; hit {
+		cache.removeEntry(e)
+		cache.mutex.Unlock() // Unlock
+		return
+	}
+	err = ErrNotFound
+	cache.mutex.Unlock() // Unlock
+	return
+}
@@ -0,0 +1,76 @@
+package lrucache
+
+import "testing"
+
+func TestTypedCacheBasic(t *testing.T) {
+	cache := NewTyped[string, int](2)
+
+	if err := cache.Add("a", 1); err != nil {
+		t.Fatalf("Add(a): %v", err)
+	}
+	if err := cache.Add("a", 1); err != ErrNotStored {
+		t.Fatalf("Add(a) again: got %v, want ErrNotStored", err)
+	}
+	if err := cache.Set("b", 2); err != nil {
+		t.Fatalf("Set(b): %v", err)
+	}
+
+	if v, err := cache.Get("a"); err != nil || v != 1 {
+		t.Fatalf("Get(a) = %v, %v; want 1, nil", v, err)
+	}
+	if _, err := cache.Get("missing"); err != ErrNotFound {
+		t.Fatalf("Get(missing) = %v; want ErrNotFound", err)
+	}
+
+	if err := cache.Remove("b"); err != nil {
+		t.Fatalf("Remove(b): %v", err)
+	}
+	if err := cache.Remove("b"); err != ErrNotFound {
+		t.Fatalf("Remove(b) again: got %v, want ErrNotFound", err)
+	}
+
+	cache.Purge()
+	if n := cache.Len(); n != 0 {
+		t.Fatalf("Len() after Purge = %d; want 0", n)
+	}
+}
+
+// TestTypedCacheCapacityReuse checks that adding at capacity evicts the
+// least recently used entry and keeps the others. That the evicted node is
+// reused rather than a new one allocated is checked by
+// TestTypedCacheAddAtCapacityAllocatesNothing.
+func TestTypedCacheCapacityReuse(t *testing.T) {
+	cache := NewTyped[string, int](2)
+
+	cache.Add("a", 1)
+	cache.Add("b", 2)
+	cache.Add("c", 3) // evicts a, the least recently used entry
+
+	if _, err := cache.Get("a"); err != ErrNotFound {
+		t.Fatalf("Get(a) after eviction = %v; want ErrNotFound", err)
+	}
+	if v, err := cache.Get("b"); err != nil || v != 2 {
+		t.Fatalf("Get(b) = %v, %v; want 2, nil", v, err)
+	}
+	if v, err := cache.Get("c"); err != nil || v != 3 {
+		t.Fatalf("Get(c) = %v, %v; want 3, nil", v, err)
+	}
+	if n := cache.Len(); n != 2 {
+		t.Fatalf("Len() = %d; want 2", n)
+	}
+}
+
+func TestTypedCacheAddAtCapacityAllocatesNothing(t *testing.T) {
+	cache := NewTyped[int, int](2)
+	cache.Add(1, 1)
+	cache.Add(2, 2)
+
+	key := 3
+	allocs := testing.AllocsPerRun(100, func() {
+		cache.Set(key, key)
+		key++
+	})
+	if allocs != 0 {
+		t.Fatalf("Set at capacity allocated %v times per op; want 0", allocs)
+	}
+}
@@ -0,0 +1,101 @@
+package lrucache
+
+import "time"
+
+// Peek returns the value associated with key, without promoting it to the
+// front of the cache. if there is no such item with the key it returns
+// ErrNotFound.
+//
+//  NOTE: the comparison operators == and != must be fully defined for
+//        operands of the key type.
+func (cache *Cache) Peek(key Key) (value Value, err error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	e, hit := cache.itemMap[key]
+	if !hit {
+		err = ErrNotFound
+		return
+	}
+	p := e.Value.(*payload)
+	if p.expired(time.Now()) {
+		err = ErrNotFound
+		return
+	}
+	value = p.value
+	return
+}
+
+// Contains reports whether key is present in the cache, without promoting
+// it to the front of the cache.
+//
+//  NOTE: the comparison operators == and != must be fully defined for
+//        operands of the key type.
+func (cache *Cache) Contains(key Key) (ok bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	e, hit := cache.itemMap[key]
+	if !hit {
+		return
+	}
+	ok = !e.Value.(*payload).expired(time.Now())
+	return
+}
+
+// Keys returns the keys currently in the cache, ordered from least to most
+// recently used.
+func (cache *Cache) Keys() []Key {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	now := time.Now()
+	keys := make([]Key, 0, cache.lruList.Len())
+	for e := cache.lruList.Back(); e != nil; e = e.Prev() {
+		if p := e.Value.(*payload); !p.expired(now) {
+			keys = append(keys, p.key)
+		}
+	}
+	return keys
+}
+
+// Oldest returns the least recently used key-value pair in the cache.
+// ok is false if the cache is empty.
+func (cache *Cache) Oldest() (key Key, value Value, ok bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	now := time.Now()
+	for e := cache.lruList.Back(); e != nil; e = e.Prev() {
+		p := e.Value.(*payload)
+		if p.expired(now) {
+			continue
+		}
+		key, value, ok = p.key, p.value, true
+		return
+	}
+	return
+}
+
+// Range calls fn for each key-value pair in the cache, in least-to-most
+// recently used order, stopping early if fn returns false. The keys and
+// values are snapshotted under the cache's mutex, and fn is invoked outside
+// it, so fn may safely call back into the cache; it may not observe
+// concurrent Add/Set/Remove that happen after the snapshot is taken.
+func (cache *Cache) Range(fn func(key Key, value Value) bool) {
+	cache.mutex.Lock()
+	now := time.Now()
+	pairs := make([]payload, 0, cache.lruList.Len())
+	for e := cache.lruList.Back(); e != nil; e = e.Prev() {
+		if p := e.Value.(*payload); !p.expired(now) {
+			pairs = append(pairs, *p)
+		}
+	}
+	cache.mutex.Unlock() // Unlock
+
+	for _, p := range pairs {
+		if !fn(p.key, p.value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package lrucache
+
+import "testing"
+
+func TestCachePeekDoesNotPromote(t *testing.T) {
+	cache := New(2)
+	cache.Set("a", 1)
+	cache.Set("b", 2) // lru order, oldest to newest: a, b
+
+	if v, err := cache.Peek("a"); err != nil || v != 1 {
+		t.Fatalf("Peek(a) = %v, %v; want 1, nil", v, err)
+	}
+
+	// If Peek had promoted a, b would now be the oldest.
+	cache.Set("c", 3) // evicts the oldest entry, if Peek didn't promote a
+
+	if _, err := cache.Get("a"); err != ErrNotFound {
+		t.Fatalf("Get(a) after Peek+eviction = %v; want ErrNotFound (Peek must not promote)", err)
+	}
+	if _, err := cache.Get("b"); err != nil {
+		t.Fatalf("Get(b) after eviction: %v", err)
+	}
+
+	if _, err := cache.Peek("missing"); err != ErrNotFound {
+		t.Fatalf("Peek(missing) = %v; want ErrNotFound", err)
+	}
+}
+
+func TestCacheContains(t *testing.T) {
+	cache := New(2)
+	cache.Set("a", 1)
+
+	if !cache.Contains("a") {
+		t.Fatalf("Contains(a) = false; want true")
+	}
+	if cache.Contains("missing") {
+		t.Fatalf("Contains(missing) = true; want false")
+	}
+}
+
+func TestCacheKeysOldestToNewest(t *testing.T) {
+	cache := New(3)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Set("c", 3)
+	cache.Get("a") // promotes a to the front
+
+	got := cache.Keys()
+	want := []Key{"b", "c", "a"}
+	if len(got) != len(want) {
+		t.Fatalf("Keys() = %v; want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Keys() = %v; want %v", got, want)
+		}
+	}
+}
+
+func TestCacheOldest(t *testing.T) {
+	cache := New(2)
+
+	if _, _, ok := cache.Oldest(); ok {
+		t.Fatalf("Oldest() on empty cache: ok = true; want false")
+	}
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	key, value, ok := cache.Oldest()
+	if !ok || key != Key("a") || value != 1 {
+		t.Fatalf("Oldest() = %v, %v, %v; want a, 1, true", key, value, ok)
+	}
+}
+
+func TestCacheRangeOrderAndEarlyStop(t *testing.T) {
+	cache := New(3)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Set("c", 3)
+
+	var visited []Key
+	cache.Range(func(key Key, value Value) bool {
+		visited = append(visited, key)
+		return true
+	})
+	want := []Key{"a", "b", "c"}
+	if len(visited) != len(want) {
+		t.Fatalf("Range visited = %v; want %v", visited, want)
+	}
+	for i := range want {
+		if visited[i] != want[i] {
+			t.Fatalf("Range visited = %v; want %v", visited, want)
+		}
+	}
+
+	visited = nil
+	cache.Range(func(key Key, value Value) bool {
+		visited = append(visited, key)
+		return key != "b"
+	})
+	if len(visited) != 2 || visited[0] != "a" || visited[1] != "b" {
+		t.Fatalf("Range with early stop visited = %v; want [a b]", visited)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 )
 
 var (
@@ -24,8 +25,14 @@ type (
 )
 
 type payload struct {
-	key   Key
-	value Value
+	key      Key
+	value    Value
+	expireAt time.Time // zero value means "no expiration"
+}
+
+// expired reports whether p carries a TTL that has already passed.
+func (p *payload) expired(now time.Time) bool {
+	return !p.expireAt.IsZero() && !now.Before(p.expireAt)
 }
 
 //                             front                           back
@@ -54,10 +61,13 @@ type payload struct {
 
 // Cache is a thread-safe fixed size LRU cache.
 type Cache struct {
-	mutex   sync.Mutex
-	size    int
-	lruList *list.List
-	itemMap map[Key]*list.Element
+	mutex      sync.Mutex
+	size       int
+	lruList    *list.List
+	itemMap    map[Key]*list.Element
+	onEvicted  func(key Key, value Value, reason EvictReason)
+	defaultTTL time.Duration // 0 means entries never expire unless given an explicit TTL via SetWithTTL
+	gcStop     chan struct{} // non-nil while a StartGC goroutine is running
 }
 
 // New creates an LRU cache of the given size. if size<=0, will panic.
@@ -87,15 +97,21 @@ func (cache *Cache) SetSize(size int) {
 	}
 
 	cache.mutex.Lock()
-	defer cache.mutex.Unlock()
-
+	var evicted []*payload
 	if n := cache.lruList.Len() - size; n > 0 {
 		for i, e := n, cache.lruList.Back(); i > 0; i, e = i-1, cache.lruList.Back() {
-			cache.remove(e)
+			evicted = append(evicted, cache.remove(e))
 		}
 	}
-
 	cache.size = size
+	onEvicted := cache.onEvicted
+	cache.mutex.Unlock() // Unlock
+
+	if onEvicted != nil {
+		for _, p := range evicted {
+			onEvicted(p.key, p.value, EvictedCapacity)
+		}
+	}
 	return
 }
 
@@ -110,41 +126,61 @@ func (cache *Cache) Len() (n int) {
 // Purge is used to completely clear the cache
 func (cache *Cache) Purge() {
 	cache.mutex.Lock()
-	defer cache.mutex.Unlock()
-
+	var evicted []*payload
+	if cache.onEvicted != nil {
+		for e := cache.lruList.Front(); e != nil; e = e.Next() {
+			evicted = append(evicted, e.Value.(*payload))
+		}
+	}
 	cache.lruList = list.New()
 	cache.itemMap = make(map[Key]*list.Element, cache.size)
+	onEvicted := cache.onEvicted
+	cache.mutex.Unlock() // Unlock
+
+	if onEvicted != nil {
+		for _, p := range evicted {
+			onEvicted(p.key, p.value, EvictedPurged)
+		}
+	}
 }
 
-// add adds key-value to cache.
-// Please ensure that there is no item with the same key in cache
-func (cache *Cache) add(key Key, value Value) (err error) {
+// add adds key-value to cache, expiring at expireAt (zero value means never).
+// Please ensure that there is no item with the same key in cache.
+// If adding at capacity evicted an existing entry, it is returned so the
+// caller can invoke cache.onEvicted once the mutex is released.
+func (cache *Cache) add(key Key, value Value, expireAt time.Time) (evicted *payload) {
 	if cache.lruList.Len() >= cache.size {
 		e := cache.lruList.Back() // e != nil, for cache.size > 0
-		payload := e.Value.(*payload)
+		p := e.Value.(*payload)
 
-		delete(cache.itemMap, payload.key)
+		delete(cache.itemMap, p.key)
+		evicted = &payload{key: p.key, value: p.value}
 
-		payload.key = key
-		payload.value = value
+		p.key = key
+		p.value = value
+		p.expireAt = expireAt
 
 		cache.itemMap[key] = e
 		cache.lruList.MoveToFront(e)
 		return
 	} else {
 		cache.itemMap[key] = cache.lruList.PushFront(&payload{
-			key:   key,
-			value: value,
+			key:      key,
+			value:    value,
+			expireAt: expireAt,
 		})
 		return
 	}
 }
 
-// remove removes the Element e from cache.lruList.
+// remove removes the Element e from cache.lruList and returns its payload
+// so the caller can invoke cache.onEvicted once the mutex is released.
 // Please ensure that e != nil and e is an element of list lruList.
-func (cache *Cache) remove(e *list.Element) {
-	delete(cache.itemMap, e.Value.(*payload).key)
+func (cache *Cache) remove(e *list.Element) (removed *payload) {
+	removed = e.Value.(*payload)
+	delete(cache.itemMap, removed.key)
 	cache.lruList.Remove(e)
+	return
 }
 
 // Add adds key-value to cache.
@@ -158,11 +194,17 @@ func (cache *Cache) Add(key Key, value Value) (err error) {
 		err = ErrNotStored
 		cache.mutex.Unlock() // Unlock
 		return
-	} else {
-		err = cache.add(key, value)
-		cache.mutex.Unlock() // Unlock
-		return
 	}
+
+	expireAt := cache.expireAt(cache.defaultTTL)
+	evicted := cache.add(key, value, expireAt)
+	onEvicted := cache.onEvicted
+	cache.mutex.Unlock() // Unlock
+
+	if onEvicted != nil && evicted != nil {
+		onEvicted(evicted.key, evicted.value, EvictedCapacity)
+	}
+	return
 }
 
 // Set sets key-value to cache, unconditional
@@ -175,14 +217,21 @@ func (cache *Cache) Set(key Key, value Value) (err error) {
 		payload := e.Value.(*payload)
 		// payload.Key = key
 		payload.value = value
+		payload.expireAt = cache.expireAt(cache.defaultTTL)
 		cache.lruList.MoveToFront(e)
 		cache.mutex.Unlock() // Unlock
 		return
-	} else {
-		err = cache.add(key, value)
-		cache.mutex.Unlock() // Unlock
-		return
 	}
+
+	expireAt := cache.expireAt(cache.defaultTTL)
+	evicted := cache.add(key, value, expireAt)
+	onEvicted := cache.onEvicted
+	cache.mutex.Unlock() // Unlock
+
+	if onEvicted != nil && evicted != nil {
+		onEvicted(evicted.key, evicted.value, EvictedCapacity)
+	}
+	return
 }
 
 // Get looks up a key's value from the cache.
@@ -192,16 +241,30 @@ func (cache *Cache) Set(key Key, value Value) (err error) {
 //        operands of the key type.
 func (cache *Cache) Get(key Key) (value Value, err error) {
 	cache.mutex.Lock()
-	if e, hit := cache.itemMap[key]; hit {
-		cache.lruList.MoveToFront(e)
-		value = e.Value.(*payload).value
+	e, hit := cache.itemMap[key]
+	if !hit {
+		err = ErrNotFound
 		cache.mutex.Unlock() // Unlock
 		return
-	} else {
-		err = ErrNotFound
+	}
+
+	p := e.Value.(*payload)
+	if p.expired(time.Now()) {
+		removed := cache.remove(e)
+		onEvicted := cache.onEvicted
 		cache.mutex.Unlock() // Unlock
+
+		if onEvicted != nil {
+			onEvicted(removed.key, removed.value, EvictedExpired)
+		}
+		err = ErrNotFound
 		return
 	}
+
+	cache.lruList.MoveToFront(e)
+	value = p.value
+	cache.mutex.Unlock() // Unlock
+	return
 }
 
 // Remove removes the provided key from the cache.
@@ -212,13 +275,19 @@ func (cache *Cache) Get(key Key) (value Value, err error) {
 //        operands of the key type.
 func (cache *Cache) Remove(key Key) (err error) {
 	cache.mutex.Lock()
-	if e, hit := cache.itemMap[key]; hit {
-		cache.remove(e)
-		cache.mutex.Unlock() // Unlock
-		return
-	} else {
+	e, hit := cache.itemMap[key]
+	if !hit {
 		err = ErrNotFound
 		cache.mutex.Unlock() // Unlock
 		return
 	}
+
+	removed := cache.remove(e)
+	onEvicted := cache.onEvicted
+	cache.mutex.Unlock() // Unlock
+
+	if onEvicted != nil {
+		onEvicted(removed.key, removed.value, EvictedRemoved)
+	}
+	return
 }
@@ -0,0 +1,150 @@
+package lrucache
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Hasher maps a key to a shard selector. It is only used to pick a shard,
+// never to detect collisions, so it need not be cryptographically strong.
+type Hasher func(key Key) uint64
+
+// defaultHasher hashes the fmt representation of key with FNV-1a. It works
+// for any Key, at the cost of being slower than a hasher tailored to a
+// specific key type.
+func defaultHasher(key Key) uint64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%v", key)
+	return h.Sum64()
+}
+
+// ShardedCache is a thread-safe fixed size LRU cache that spreads its
+// entries across N independent Cache shards, keyed by Hasher(key) % N, so
+// that lock contention on Get/Set is spread across shards instead of
+// serialized behind a single mutex.
+type ShardedCache struct {
+	shards []*Cache
+	hasher Hasher
+}
+
+// NewSharded creates a ShardedCache of the given total size split evenly
+// across shards. Each shard gets a capacity of size/shards (at least 1), so
+// the effective total capacity is shards*max(size/shards, 1): slightly less
+// than size when size is not a multiple of shards, and shards (more than
+// size) when size < shards. if size<=0 or
+// shards<=0, will panic. The default Hasher hashes key with FNV-1a over its
+// fmt representation; pass a Hasher tailored to a specific key type via
+// SetHasher for a faster or more uniform distribution.
+func NewSharded(size, shards int) *ShardedCache {
+	if size <= 0 {
+		panic(fmt.Sprintf("size must be > 0 and now == %d", size))
+	}
+	if shards <= 0 {
+		panic(fmt.Sprintf("shards must be > 0 and now == %d", shards))
+	}
+
+	shardSize := size / shards
+	if shardSize <= 0 {
+		shardSize = 1
+	}
+
+	cache := &ShardedCache{
+		shards: make([]*Cache, shards),
+		hasher: defaultHasher,
+	}
+	for i := range cache.shards {
+		cache.shards[i] = New(shardSize)
+	}
+	return cache
+}
+
+// SetHasher overrides the Hasher used to pick a key's shard. It must be
+// called before the cache is used by more than one goroutine, and should
+// not be changed afterwards: keys hashed with a previous Hasher become
+// unreachable under a new one until they are naturally evicted.
+func (cache *ShardedCache) SetHasher(hasher Hasher) {
+	if hasher == nil {
+		return
+	}
+	cache.hasher = hasher
+}
+
+// shardFor returns the shard responsible for key.
+func (cache *ShardedCache) shardFor(key Key) *Cache {
+	i := cache.hasher(key) % uint64(len(cache.shards))
+	return cache.shards[i]
+}
+
+// Size returns the total configured size of the cache, summed across shards.
+func (cache *ShardedCache) Size() (size int) {
+	for _, shard := range cache.shards {
+		size += shard.Size()
+	}
+	return
+}
+
+// SetSize redistributes size evenly across shards, same rules as NewSharded.
+func (cache *ShardedCache) SetSize(size int) {
+	if size <= 0 {
+		return
+	}
+
+	shardSize := size / len(cache.shards)
+	if shardSize <= 0 {
+		shardSize = 1
+	}
+	for _, shard := range cache.shards {
+		shard.SetSize(shardSize)
+	}
+}
+
+// Len returns the number of items in the cache, summed across shards.
+func (cache *ShardedCache) Len() (n int) {
+	for _, shard := range cache.shards {
+		n += shard.Len()
+	}
+	return
+}
+
+// Purge is used to completely clear the cache.
+func (cache *ShardedCache) Purge() {
+	for _, shard := range cache.shards {
+		shard.Purge()
+	}
+}
+
+// Add adds key-value to cache.
+// if there already exists a item with the same key, it returns ErrNotStored.
+//
+//  NOTE: the comparison operators == and != must be fully defined for
+//        operands of the key type.
+func (cache *ShardedCache) Add(key Key, value Value) error {
+	return cache.shardFor(key).Add(key, value)
+}
+
+// Set sets key-value to cache, unconditional
+//
+//  NOTE: the comparison operators == and != must be fully defined for
+//        operands of the key type.
+func (cache *ShardedCache) Set(key Key, value Value) error {
+	return cache.shardFor(key).Set(key, value)
+}
+
+// Get looks up a key's value from the cache.
+// if there is no such item with the key it returns ErrNotFound.
+//
+//  NOTE: the comparison operators == and != must be fully defined for
+//        operands of the key type.
+func (cache *ShardedCache) Get(key Key) (Value, error) {
+	return cache.shardFor(key).Get(key)
+}
+
+// Remove removes the provided key from the cache.
+// if there is no such item with the key it returns ErrNotFound,
+// normally you can ignore this error.
+//
+//  NOTE: the comparison operators == and != must be fully defined for
+//        operands of the key type.
+func (cache *ShardedCache) Remove(key Key) error {
+	return cache.shardFor(key).Remove(key)
+}
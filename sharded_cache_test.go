@@ -0,0 +1,113 @@
+package lrucache
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+func TestShardedCacheBasic(t *testing.T) {
+	cache := NewSharded(8, 4)
+
+	if err := cache.Add("a", 1); err != nil {
+		t.Fatalf("Add(a): %v", err)
+	}
+	if err := cache.Add("a", 1); err != ErrNotStored {
+		t.Fatalf("Add(a) again: got %v, want ErrNotStored", err)
+	}
+	if err := cache.Set("b", 2); err != nil {
+		t.Fatalf("Set(b): %v", err)
+	}
+
+	if v, err := cache.Get("a"); err != nil || v != 1 {
+		t.Fatalf("Get(a) = %v, %v; want 1, nil", v, err)
+	}
+	if _, err := cache.Get("missing"); err != ErrNotFound {
+		t.Fatalf("Get(missing) = %v; want ErrNotFound", err)
+	}
+	if n := cache.Len(); n != 2 {
+		t.Fatalf("Len() = %d; want 2", n)
+	}
+
+	if err := cache.Remove("b"); err != nil {
+		t.Fatalf("Remove(b): %v", err)
+	}
+	if err := cache.Remove("b"); err != ErrNotFound {
+		t.Fatalf("Remove(b) again: got %v, want ErrNotFound", err)
+	}
+
+	cache.Purge()
+	if n := cache.Len(); n != 0 {
+		t.Fatalf("Len() after Purge = %d; want 0", n)
+	}
+
+	cache.SetSize(16)
+	if size := cache.Size(); size != 16 {
+		t.Fatalf("Size() after SetSize(16) = %d; want 16", size)
+	}
+}
+
+// TestShardedCacheCustomHasher checks that SetHasher's Hasher, not the
+// default one, actually decides which shard a key lands on: forcing every
+// key into the same shard makes that shard's own (much smaller) capacity
+// the one that governs eviction.
+func TestShardedCacheCustomHasher(t *testing.T) {
+	cache := NewSharded(4, 4) // 1 slot per shard
+	cache.SetHasher(func(key Key) uint64 { return 0 })
+
+	cache.Set("a", 1)
+	cache.Set("b", 2) // same shard as a, which only holds 1 entry
+
+	if _, err := cache.Get("a"); err != ErrNotFound {
+		t.Fatalf("Get(a) after same-shard eviction = %v; want ErrNotFound", err)
+	}
+	if v, err := cache.Get("b"); err != nil || v != 2 {
+		t.Fatalf("Get(b) = %v, %v; want 2, nil", v, err)
+	}
+}
+
+func TestShardedCacheSetHasherIgnoresNil(t *testing.T) {
+	cache := NewSharded(8, 4)
+	cache.SetHasher(nil) // must not panic, must not clear the default hasher
+
+	cache.Set("a", 1)
+	if _, err := cache.Get("a"); err != nil {
+		t.Fatalf("Get(a): %v", err)
+	}
+}
+
+func BenchmarkCacheContended(b *testing.B) {
+	const keySpace = 1000
+	cache := New(keySpace / 10)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		var i int64
+		for pb.Next() {
+			key := int(atomic.AddInt64(&i, 1)) % keySpace
+			if _, err := cache.Get(key); err != nil {
+				cache.Set(key, key)
+			}
+		}
+	})
+}
+
+func BenchmarkShardedCacheContended(b *testing.B) {
+	const keySpace = 1000
+	for _, shards := range []int{2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			cache := NewSharded(keySpace/10, shards)
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				var i int64
+				for pb.Next() {
+					key := int(atomic.AddInt64(&i, 1)) % keySpace
+					if _, err := cache.Get(key); err != nil {
+						cache.Set(key, key)
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package lrucache
+
+import (
+	"fmt"
+	"sync"
+)
+
+// sieveNode is a node of the intrusive doubly-linked list used by SieveCache.
+type sieveNode struct {
+	key        Key
+	value      Value
+	visited    bool
+	prev, next *sieveNode
+}
+
+// SieveCache is a thread-safe fixed size cache using the SIEVE eviction
+// algorithm (see https://cachemon.github.io/SIEVE-website/) instead of LRU.
+//
+// SIEVE keeps a single FIFO queue of entries, each carrying a 1-bit
+// "visited" flag. A Get only sets visited=true and never moves the node,
+// so hits are lock-light and never touch the list pointers. Eviction walks
+// a "hand" pointer backward from the tail: while the pointed entry is
+// visited, its bit is cleared and the hand advances; the first unvisited
+// entry found is evicted and the hand is left at its predecessor. New
+// entries are always inserted at the head with visited=false.
+type SieveCache struct {
+	mutex sync.Mutex
+	size  int
+	root  sieveNode // sentinel; root.next = head (newest), root.prev = tail (oldest)
+	items map[Key]*sieveNode
+	hand  *sieveNode // nil means "start the next sweep from the tail"
+}
+
+// NewSieve creates a SIEVE cache of the given size. if size<=0, will panic.
+func NewSieve(size int) *SieveCache {
+	if size <= 0 {
+		panic(fmt.Sprintf("size must be > 0 and now == %d", size))
+	}
+	cache := &SieveCache{
+		size:  size,
+		items: make(map[Key]*sieveNode, size),
+	}
+	cache.root.prev = &cache.root
+	cache.root.next = &cache.root
+	return cache
+}
+
+// Size returns the size of cache.
+func (cache *SieveCache) Size() (size int) {
+	cache.mutex.Lock()
+	size = cache.size
+	cache.mutex.Unlock()
+	return
+}
+
+// SetSize sets a new size for the cache. if size <=0, we do nothing.
+func (cache *SieveCache) SetSize(size int) {
+	if size <= 0 {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	for n := len(cache.items) - size; n > 0; n-- {
+		cache.evict()
+	}
+
+	cache.size = size
+	return
+}
+
+// Len returns the number of items in the cache.
+func (cache *SieveCache) Len() (n int) {
+	cache.mutex.Lock()
+	n = len(cache.items)
+	cache.mutex.Unlock()
+	return
+}
+
+// Purge is used to completely clear the cache
+func (cache *SieveCache) Purge() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.root.prev = &cache.root
+	cache.root.next = &cache.root
+	cache.items = make(map[Key]*sieveNode, cache.size)
+	cache.hand = nil
+}
+
+// pushFront inserts n as the head (newest) entry.
+func (cache *SieveCache) pushFront(n *sieveNode) {
+	n.prev = &cache.root
+	n.next = cache.root.next
+	n.prev.next = n
+	n.next.prev = n
+}
+
+// removeNode unlinks n from the list and the map.
+// Please ensure that n != &cache.root and n is a node of the list.
+func (cache *SieveCache) removeNode(n *sieveNode) {
+	delete(cache.items, n.key)
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	n.prev = nil
+	n.next = nil
+}
+
+// evict runs the SIEVE hand over the list and removes the first unvisited
+// entry it finds, leaving the hand at the victim's predecessor.
+// Please ensure that the list is not empty.
+func (cache *SieveCache) evict() {
+	o := cache.hand
+	if o == nil {
+		o = cache.root.prev // tail
+	}
+	for o != &cache.root && o.visited {
+		o.visited = false
+		o = o.prev
+		if o == &cache.root {
+			o = cache.root.prev // wrap back to tail
+		}
+	}
+
+	if prev := o.prev; prev == &cache.root {
+		cache.hand = nil
+	} else {
+		cache.hand = prev
+	}
+	cache.removeNode(o)
+}
+
+// add adds key-value to cache.
+// Please ensure that there is no item with the same key in cache
+func (cache *SieveCache) add(key Key, value Value) {
+	if len(cache.items) >= cache.size {
+		cache.evict()
+	}
+
+	n := &sieveNode{key: key, value: value}
+	cache.items[key] = n
+	cache.pushFront(n)
+}
+
+// Add adds key-value to cache.
+// if there already exists a item with the same key, it returns ErrNotStored.
+//
+//  NOTE: the comparison operators == and != must be fully defined for
+//        operands of the key type.
+func (cache *SieveCache) Add(key Key, value Value) (err error) {
+	cache.mutex.Lock()
+	if _, hit := cache.items[key]; hit {
+		err = ErrNotStored
+		cache.mutex.Unlock() // Unlock
+		return
+	}
+	cache.add(key, value)
+	cache.mutex.Unlock() // Unlock
+	return
+}
+
+// Set sets key-value to cache, unconditional
+//
+//  NOTE: the comparison operators == and != must be fully defined for
+//        operands of the key type.
+func (cache *SieveCache) Set(key Key, value Value) (err error) {
+	cache.mutex.Lock()
+	if n, hit := cache.items[key]; hit {
+		n.value = value
+		cache.mutex.Unlock() // Unlock
+		return
+	}
+	cache.add(key, value)
+	cache.mutex.Unlock() // Unlock
+	return
+}
+
+// Get looks up a key's value from the cache. A hit only sets the entry's
+// visited bit; it never moves the entry within the list.
+// if there is no such item with the key it returns ErrNotFound.
+//
+//  NOTE: the comparison operators == and != must be fully defined for
+//        operands of the key type.
+func (cache *SieveCache) Get(key Key) (value Value, err error) {
+	cache.mutex.Lock()
+	if n, hit := cache.items[key]; hit {
+		n.visited = true
+		value = n.value
+		cache.mutex.Unlock() // Unlock
+		return
+	}
+	err = ErrNotFound
+	cache.mutex.Unlock() // Unlock
+	return
+}
+
+// Remove removes the provided key from the cache.
+// if there is no such item with the key it returns ErrNotFound,
+// normally you can ignore this error.
+//
+//  NOTE: the comparison operators == and != must be fully defined for
+//        operands of the key type.
+func (cache *SieveCache) Remove(key Key) (err error) {
+	cache.mutex.Lock()
+	if n, hit := cache.items[key]; hit {
+		if cache.hand == n {
+			if n.prev == &cache.root {
+				cache.hand = nil
+			} else {
+				cache.hand = n.prev
+			}
+		}
+		cache.removeNode(n)
+		cache.mutex.Unlock() // Unlock
+		return
+	}
+	err = ErrNotFound
+	cache.mutex.Unlock() // Unlock
+	return
+}
@@ -0,0 +1,134 @@
+package lrucache
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSieveCacheBasic(t *testing.T) {
+	cache := NewSieve(2)
+
+	if err := cache.Add("a", 1); err != nil {
+		t.Fatalf("Add(a): %v", err)
+	}
+	if err := cache.Add("a", 1); err != ErrNotStored {
+		t.Fatalf("Add(a) again: got %v, want ErrNotStored", err)
+	}
+	if err := cache.Set("b", 2); err != nil {
+		t.Fatalf("Set(b): %v", err)
+	}
+
+	if v, err := cache.Get("a"); err != nil || v != 1 {
+		t.Fatalf("Get(a) = %v, %v; want 1, nil", v, err)
+	}
+	if _, err := cache.Get("missing"); err != ErrNotFound {
+		t.Fatalf("Get(missing) = %v; want ErrNotFound", err)
+	}
+
+	if err := cache.Remove("b"); err != nil {
+		t.Fatalf("Remove(b): %v", err)
+	}
+	if err := cache.Remove("b"); err != ErrNotFound {
+		t.Fatalf("Remove(b) again: got %v, want ErrNotFound", err)
+	}
+
+	cache.Purge()
+	if n := cache.Len(); n != 0 {
+		t.Fatalf("Len() after Purge = %d; want 0", n)
+	}
+}
+
+// TestSieveCacheEviction exercises the hand-wrap logic: entries with their
+// visited bit set survive a sweep (the bit is merely cleared), and the
+// first unvisited entry the hand reaches is evicted, even if it is the most
+// recently inserted one.
+func TestSieveCacheEviction(t *testing.T) {
+	cache := NewSieve(3)
+
+	cache.Add("a", 1)
+	cache.Add("b", 2)
+	cache.Add("c", 3) // head (newest), never accessed below
+
+	if _, err := cache.Get("a"); err != nil {
+		t.Fatalf("Get(a): %v", err)
+	}
+	if _, err := cache.Get("b"); err != nil {
+		t.Fatalf("Get(b): %v", err)
+	}
+
+	// Capacity is full; this eviction must skip the visited a and b, clear
+	// their visited bits, and evict c instead, even though c is newer.
+	if err := cache.Add("d", 4); err != nil {
+		t.Fatalf("Add(d): %v", err)
+	}
+
+	if _, err := cache.Get("c"); err != ErrNotFound {
+		t.Fatalf("Get(c) after eviction = %v; want ErrNotFound", err)
+	}
+	for _, key := range []Key{"a", "b", "d"} {
+		if _, err := cache.Get(key); err != nil {
+			t.Fatalf("Get(%v) after eviction: %v", key, err)
+		}
+	}
+	if n := cache.Len(); n != 3 {
+		t.Fatalf("Len() after eviction = %d; want 3", n)
+	}
+
+	// The Get calls above set the visited bits of a, b and d again, so this
+	// eviction must clear all three, wrap back to the tail and still evict
+	// one of them.
+	if err := cache.Add("e", 5); err != nil {
+		t.Fatalf("Add(e): %v", err)
+	}
+	if n := cache.Len(); n != 3 {
+		t.Fatalf("Len() after second eviction = %d; want 3", n)
+	}
+}
+
+// zipfKeys returns n keys drawn from a Zipfian distribution over
+// [0, imax], biased toward small values as real-world cache workloads are.
+func zipfKeys(n int, imax uint64) []uint64 {
+	rng := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(rng, 1.1, 1, imax)
+	keys := make([]uint64, n)
+	for i := range keys {
+		keys[i] = zipf.Uint64()
+	}
+	return keys
+}
+
+func BenchmarkSieveCacheZipf(b *testing.B) {
+	const keySpace = 10000
+	cache := NewSieve(1000)
+	keys := zipfKeys(b.N, keySpace)
+
+	var hits int64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := keys[i]
+		if _, err := cache.Get(key); err == nil {
+			hits++
+		} else {
+			cache.Set(key, key)
+		}
+	}
+	b.ReportMetric(float64(hits)/float64(b.N)*100, "hit%")
+}
+
+func BenchmarkLRUCacheZipf(b *testing.B) {
+	const keySpace = 10000
+	cache := New(1000)
+	keys := zipfKeys(b.N, keySpace)
+
+	var hits int64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := keys[i]
+		if _, err := cache.Get(key); err == nil {
+			hits++
+		} else {
+			cache.Set(key, key)
+		}
+	}
+	b.ReportMetric(float64(hits)/float64(b.N)*100, "hit%")
+}
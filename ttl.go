@@ -0,0 +1,157 @@
+package lrucache
+
+import "time"
+
+// EvictReason describes why an entry left the cache. It is passed to the
+// OnEvicted callback registered via NewWithEvict.
+type EvictReason int
+
+const (
+	// EvictedCapacity is reported when an entry is evicted to make room for a new one.
+	EvictedCapacity EvictReason = iota
+	// EvictedRemoved is reported when an entry is evicted by an explicit Remove.
+	EvictedRemoved
+	// EvictedPurged is reported when an entry is evicted by Purge.
+	EvictedPurged
+	// EvictedExpired is reported when an entry is evicted because its TTL elapsed.
+	EvictedExpired
+)
+
+// NewWithEvict creates an LRU cache of the given size that invokes onEvicted
+// whenever an entry leaves the cache, whether by capacity eviction, Remove,
+// Purge, or TTL expiration. onEvicted is always invoked with the cache's
+// mutex released, so it is safe for it to call back into the cache.
+func NewWithEvict(size int, onEvicted func(key Key, value Value, reason EvictReason)) *Cache {
+	cache := New(size)
+	cache.onEvicted = onEvicted
+	return cache
+}
+
+// NewWithTTL creates an LRU cache of the given size in which every entry
+// added via Add or Set expires after ttl. Use SetWithTTL to give an
+// individual entry a different lifetime. A non-positive ttl means entries
+// never expire, same as New.
+func NewWithTTL(size int, ttl time.Duration) *Cache {
+	cache := New(size)
+	cache.defaultTTL = ttl
+	return cache
+}
+
+// NewWithEvictAndTTL creates an LRU cache of the given size that combines
+// NewWithEvict and NewWithTTL: every entry added via Add or Set expires
+// after ttl, and onEvicted is invoked whenever an entry leaves the cache,
+// whether by capacity eviction, Remove, Purge, or TTL expiration.
+func NewWithEvictAndTTL(size int, onEvicted func(key Key, value Value, reason EvictReason), ttl time.Duration) *Cache {
+	cache := New(size)
+	cache.onEvicted = onEvicted
+	cache.defaultTTL = ttl
+	return cache
+}
+
+// expireAt returns the absolute expiration time for a TTL of d, or the zero
+// Time if d means "never expires".
+func (cache *Cache) expireAt(d time.Duration) time.Time {
+	if d <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(d)
+}
+
+// SetWithTTL sets key-value to cache, unconditional, expiring after ttl.
+// A non-positive ttl means the entry never expires, regardless of the
+// cache's default TTL.
+//
+//  NOTE: the comparison operators == and != must be fully defined for
+//        operands of the key type.
+func (cache *Cache) SetWithTTL(key Key, value Value, ttl time.Duration) (err error) {
+	cache.mutex.Lock()
+	expireAt := cache.expireAt(ttl)
+	if e, hit := cache.itemMap[key]; hit {
+		payload := e.Value.(*payload)
+		payload.value = value
+		payload.expireAt = expireAt
+		cache.lruList.MoveToFront(e)
+		cache.mutex.Unlock() // Unlock
+		return
+	}
+
+	evicted := cache.add(key, value, expireAt)
+	onEvicted := cache.onEvicted
+	cache.mutex.Unlock() // Unlock
+
+	if onEvicted != nil && evicted != nil {
+		onEvicted(evicted.key, evicted.value, EvictedCapacity)
+	}
+	return
+}
+
+// StartGC launches a background goroutine that scans the cache every
+// interval and evicts entries whose TTL has elapsed, invoking OnEvicted (if
+// set) with EvictedExpired for each. Calling StartGC while a scan is
+// already running is a no-op; call StopGC first to restart with a new
+// interval. if interval<=0, we do nothing, since time.NewTicker would
+// otherwise panic from inside the background goroutine.
+func (cache *Cache) StartGC(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	cache.mutex.Lock()
+	if cache.gcStop != nil {
+		cache.mutex.Unlock() // Unlock
+		return
+	}
+	stop := make(chan struct{})
+	cache.gcStop = stop
+	cache.mutex.Unlock() // Unlock
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				cache.reapExpired()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopGC stops the goroutine started by StartGC, if any. It is safe to call
+// even if StartGC was never called or has already been stopped.
+func (cache *Cache) StopGC() {
+	cache.mutex.Lock()
+	stop := cache.gcStop
+	cache.gcStop = nil
+	cache.mutex.Unlock() // Unlock
+
+	if stop != nil {
+		close(stop)
+	}
+}
+
+// reapExpired removes every expired entry from the cache, invoking
+// cache.onEvicted (if set) with EvictedExpired for each, outside the mutex.
+func (cache *Cache) reapExpired() {
+	now := time.Now()
+
+	cache.mutex.Lock()
+	var expired []*payload
+	for e := cache.lruList.Front(); e != nil; {
+		next := e.Next()
+		if e.Value.(*payload).expired(now) {
+			expired = append(expired, cache.remove(e))
+		}
+		e = next
+	}
+	onEvicted := cache.onEvicted
+	cache.mutex.Unlock() // Unlock
+
+	if onEvicted != nil {
+		for _, p := range expired {
+			onEvicted(p.key, p.value, EvictedExpired)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package lrucache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCacheTTLExpiryIsAMiss(t *testing.T) {
+	cache := NewWithTTL(2, time.Millisecond)
+
+	cache.Set("a", 1)
+	if _, err := cache.Get("a"); err != nil {
+		t.Fatalf("Get(a) before expiry: %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := cache.Get("a"); err != ErrNotFound {
+		t.Fatalf("Get(a) after expiry = %v; want ErrNotFound", err)
+	}
+	if n := cache.Len(); n != 0 {
+		t.Fatalf("Len() after expired Get = %d; want 0", n)
+	}
+}
+
+func TestCacheSetWithTTLOverridesDefault(t *testing.T) {
+	cache := NewWithTTL(2, time.Hour)
+
+	cache.SetWithTTL("a", 1, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := cache.Get("a"); err != ErrNotFound {
+		t.Fatalf("Get(a) after per-entry TTL expiry = %v; want ErrNotFound", err)
+	}
+}
+
+// evictLog records OnEvicted calls under a mutex, since they fire from
+// whichever goroutine triggered the eviction.
+type evictLog struct {
+	mu    sync.Mutex
+	calls []struct {
+		key    Key
+		value  Value
+		reason EvictReason
+	}
+}
+
+func (l *evictLog) record(key Key, value Value, reason EvictReason) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, struct {
+		key    Key
+		value  Value
+		reason EvictReason
+	}{key, value, reason})
+}
+
+func (l *evictLog) reasons() []EvictReason {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	reasons := make([]EvictReason, len(l.calls))
+	for i, c := range l.calls {
+		reasons[i] = c.reason
+	}
+	return reasons
+}
+
+func TestCacheOnEvictedCapacity(t *testing.T) {
+	log := &evictLog{}
+	cache := NewWithEvict(2, log.record)
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Set("c", 3) // evicts a, the least recently used entry
+
+	reasons := log.reasons()
+	if len(reasons) != 1 || reasons[0] != EvictedCapacity {
+		t.Fatalf("reasons = %v; want [EvictedCapacity]", reasons)
+	}
+	if log.calls[0].key != "a" || log.calls[0].value != 1 {
+		t.Fatalf("evicted = %v, %v; want a, 1", log.calls[0].key, log.calls[0].value)
+	}
+}
+
+func TestCacheOnEvictedRemove(t *testing.T) {
+	log := &evictLog{}
+	cache := NewWithEvict(2, log.record)
+
+	cache.Set("a", 1)
+	if err := cache.Remove("a"); err != nil {
+		t.Fatalf("Remove(a): %v", err)
+	}
+
+	reasons := log.reasons()
+	if len(reasons) != 1 || reasons[0] != EvictedRemoved {
+		t.Fatalf("reasons = %v; want [EvictedRemoved]", reasons)
+	}
+}
+
+func TestCacheOnEvictedPurge(t *testing.T) {
+	log := &evictLog{}
+	cache := NewWithEvict(2, log.record)
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Purge()
+
+	reasons := log.reasons()
+	if len(reasons) != 2 || reasons[0] != EvictedPurged || reasons[1] != EvictedPurged {
+		t.Fatalf("reasons = %v; want two EvictedPurged", reasons)
+	}
+}
+
+func TestCacheOnEvictedExpiredViaGet(t *testing.T) {
+	log := &evictLog{}
+	cache := NewWithEvictAndTTL(2, log.record, time.Millisecond)
+
+	cache.Set("a", 1)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := cache.Get("a"); err != ErrNotFound {
+		t.Fatalf("Get(a) after expiry = %v; want ErrNotFound", err)
+	}
+
+	reasons := log.reasons()
+	if len(reasons) != 1 || reasons[0] != EvictedExpired {
+		t.Fatalf("reasons = %v; want [EvictedExpired]", reasons)
+	}
+}
+
+func TestCacheStartStopGCReapsExpired(t *testing.T) {
+	log := &evictLog{}
+	cache := NewWithEvictAndTTL(2, log.record, 2*time.Millisecond)
+	defer cache.StopGC()
+
+	cache.Set("a", 1)
+	cache.StartGC(time.Millisecond)
+
+	deadline := time.Now().Add(time.Second)
+	for cache.Len() != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if n := cache.Len(); n != 0 {
+		t.Fatalf("Len() after GC sweep = %d; want 0", n)
+	}
+
+	reasons := log.reasons()
+	if len(reasons) != 1 || reasons[0] != EvictedExpired {
+		t.Fatalf("reasons = %v; want [EvictedExpired]", reasons)
+	}
+
+	cache.StopGC()
+	cache.Set("b", 2)
+	time.Sleep(10 * time.Millisecond)
+	if n := cache.Len(); n != 1 {
+		t.Fatalf("Len() after StopGC = %d; want 1 (GC must not still be running)", n)
+	}
+}
+
+func TestCacheStartGCNonPositiveIntervalIsNoop(t *testing.T) {
+	cache := NewWithTTL(2, time.Millisecond)
+	cache.StartGC(0) // must not panic
+	cache.StopGC()
+}